@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+)
+
+const cacheKey = "vecrosim:base"
+
+// cacheMetrics holds the Redis cache tier's Prometheus collectors. These
+// must be registered exactly once per process, so construct a single
+// cacheMetrics with newCacheMetrics and reuse it across transports,
+// binding the transport label with cachingMiddleware.
+type cacheMetrics struct {
+	hits    *kitprometheus.Counter
+	misses  *kitprometheus.Counter
+	latency *kitprometheus.Histogram
+}
+
+func newCacheMetrics(subsystem, name string) cacheMetrics {
+	return cacheMetrics{
+		hits: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "cache_hits_total",
+			Help:      "Number of requests served from the Redis cache tier.",
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"transport"}),
+		misses: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "cache_misses_total",
+			Help:      "Number of requests that fell through to Mongo.",
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"transport"}),
+		latency: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "cache_latency_histogram",
+			Help:      "Processing time taken of the Redis Get/Set calls in seconds.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1},
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"transport"}),
+	}
+}
+
+// cachingMiddleware sits between loggingMiddleware and the Mongo-backed
+// baseService, probabilistically short-circuiting reads to Redis so
+// operators can model a cache-fronted service without paying the
+// dbReadOps cost on every request.
+func cachingMiddleware(client *redis.Client, hitRatio float64, ttl time.Duration, m cacheMetrics, transport string, logger log.Logger) ServiceMiddleware {
+	return func(next BaseService) BaseService {
+		return cachingService{
+			next:     next,
+			client:   client,
+			hitRatio: hitRatio,
+			ttl:      ttl,
+			hits:     m.hits.With("transport", transport),
+			misses:   m.misses.With("transport", transport),
+			latency:  m.latency.With("transport", transport),
+			logger:   logger,
+		}
+	}
+}
+
+type cachingService struct {
+	next     BaseService
+	client   *redis.Client
+	hitRatio float64
+	ttl      time.Duration
+	hits     metrics.Counter
+	misses   metrics.Counter
+	latency  metrics.Histogram
+	logger   log.Logger
+}
+
+func (s cachingService) DoBase(ctx context.Context) error {
+	if rand.Float64() < s.hitRatio {
+		if hit, ok := s.redisGet(ctx); ok {
+			_ = hit
+			s.hits.Add(1)
+			return nil
+		}
+	}
+
+	s.misses.Add(1)
+	if err := s.next.DoBase(ctx); err != nil {
+		return err
+	}
+
+	s.redisSet(ctx)
+	return nil
+}
+
+// redisGet issues the cache lookup, scoping the "RedisLookup" span and
+// cache_latency_histogram to just the Redis round-trip rather than the
+// Mongo fallback that may follow a miss.
+func (s cachingService) redisGet(ctx context.Context) (string, bool) {
+	tracer := otel.Tracer("vecro-service")
+	ctx, span := tracer.Start(ctx, "RedisLookup")
+	defer span.End()
+
+	begin := time.Now()
+	defer func() { s.latency.Observe(time.Since(begin).Seconds()) }()
+
+	result, err := s.client.Get(ctx, cacheKey).Result()
+	if err != nil {
+		return "", false
+	}
+	return result, true
+}
+
+// redisSet populates the cache after a Mongo fallback. A caching tier is
+// optional infrastructure: if Redis is briefly unreachable we log it and
+// still report the request as successful, rather than failing a request
+// that Mongo already served correctly.
+func (s cachingService) redisSet(ctx context.Context) {
+	tracer := otel.Tracer("vecro-service")
+	ctx, span := tracer.Start(ctx, "RedisLookup")
+	defer span.End()
+
+	begin := time.Now()
+	defer func() { s.latency.Observe(time.Since(begin).Seconds()) }()
+
+	if err := s.client.Set(ctx, cacheKey, "1", s.ttl).Err(); err != nil {
+		s.logger.Log("err", err, "msg", "redis set failed")
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/sd/consul"
+)
+
+// registerConsulService registers this VECROsim instance under name in
+// Consul, with an HTTP health check pointed at /health, and returns a
+// deregister func to be called on shutdown.
+func registerConsulService(consulAddr, name, listenAddress string, logger log.Logger) (*consul.Client, string, func(), error) {
+	cfg := consulapi.DefaultConfig()
+	cfg.Address = consulAddr
+	apiClient, err := consulapi.NewClient(cfg)
+	if err != nil {
+		return nil, "", func() {}, err
+	}
+	client := consul.NewClient(apiClient)
+
+	host, port, err := splitHostPort(listenAddress)
+	if err != nil {
+		return nil, "", func() {}, err
+	}
+
+	serviceID := fmt.Sprintf("%s-%s-%d", name, host, port)
+	registration := &consulapi.AgentServiceRegistration{
+		ID:      serviceID,
+		Name:    name,
+		Address: host,
+		Port:    port,
+		Check: &consulapi.AgentServiceCheck{
+			HTTP:     fmt.Sprintf("http://%s:%d/health", host, port),
+			Interval: "10s",
+			Timeout:  "2s",
+		},
+	}
+
+	if err := client.Register(registration); err != nil {
+		return nil, "", func() {}, err
+	}
+	logger.Log("msg", "registered with consul", "service_id", serviceID)
+
+	deregister := func() {
+		if err := client.Deregister(registration); err != nil {
+			logger.Log("err", err, "msg", "failed to deregister from consul")
+		}
+	}
+	return client, serviceID, deregister, nil
+}
+
+// splitHostPort parses addr (":8080" or "0.0.0.0:8080") into a host
+// Consul can dial and the numeric port it listens on. addr's host part
+// is almost always empty (VECRO_LISTEN_ADDRESS defaults to ":8080"), so
+// an empty host falls back to this instance's routable address rather
+// than "localhost" — otherwise every registered instance would resolve
+// to the caller's own loopback and downstream calls could never cross
+// pod/container boundaries.
+func splitHostPort(addr string) (string, int, error) {
+	parts := strings.Split(addr, ":")
+	host := parts[0]
+	if host == "" || host == "0.0.0.0" {
+		host = advertiseAddress()
+	}
+	port, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "", 0, err
+	}
+	return host, port, nil
+}
+
+// advertiseAddress resolves the address other instances should use to
+// reach this one, preferring the pod IP Kubernetes injects, then the
+// container hostname, then finally loopback for bare local runs.
+func advertiseAddress() string {
+	if podIP := os.Getenv("POD_IP"); podIP != "" {
+		return podIP
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "localhost"
+}
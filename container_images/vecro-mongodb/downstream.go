@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-kit/kit/sd/consul"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// downstreamMetrics holds the outbound-call Prometheus collectors. These
+// must be registered exactly once per process, so construct a single
+// downstreamMetrics with newDownstreamMetrics and reuse it across
+// transports, binding the transport label with downstreamMiddleware.
+type downstreamMetrics struct {
+	requestCount     *kitprometheus.Counter
+	latencyHistogram *kitprometheus.Histogram
+}
+
+func newDownstreamMetrics(subsystem, name string) downstreamMetrics {
+	return downstreamMetrics{
+		requestCount: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "downstream_request_count",
+			Help:      "Number of outbound downstream requests issued.",
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"downstream", "transport"}),
+		latencyHistogram: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "downstream_latency_histogram",
+			Help:      "Processing time taken of outbound downstream requests in seconds.",
+			Buckets:   []float64{.0002, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 15, 25},
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"downstream", "transport"}),
+	}
+}
+
+// downstreamMiddleware issues one outbound call per configured downstream
+// service after the local request completes, resolving a live instance
+// from Consul each time so callers don't hardcode downstream addresses
+// in Kubernetes manifests.
+func downstreamMiddleware(client *consul.Client, services []string, m downstreamMetrics, transport string, logger log.Logger) endpoint.Middleware {
+	tracer := otel.Tracer("vecro-service")
+
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			response, err := next(ctx, request)
+			if err != nil {
+				return response, err
+			}
+
+			if r, ok := ctx.Value(contextKeyRequest).(*http.Request); ok {
+				ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+			}
+
+			for _, downstream := range services {
+				callDownstream(ctx, tracer, client, downstream, m, transport, logger)
+			}
+			return response, err
+		}
+	}
+}
+
+// callDownstream resolves one healthy instance of downstream from Consul
+// and issues an HTTP call against it, propagating the current trace
+// context so the call joins the caller's trace in Jaeger.
+//
+// Dispatch is HTTP-only: downstream instances are always called with an
+// HTTP GET; the pb.BaseServiceClient stubs are not used, so outbound
+// calls never go over gRPC.
+func callDownstream(ctx context.Context, tracer trace.Tracer, client *consul.Client, downstream string, m downstreamMetrics, transport string, logger log.Logger) {
+	entries, _, err := client.Service(downstream, "", true, nil)
+	if err != nil || len(entries) == 0 {
+		logger.Log("err", err, "msg", "no healthy instances", "downstream", downstream)
+		return
+	}
+	entry := entries[rand.Intn(len(entries))]
+	address := entry.Service.Address
+	if address == "" {
+		address = entry.Node.Address
+	}
+	instance := fmt.Sprintf("%s:%d", address, entry.Service.Port)
+
+	ctx, span := tracer.Start(ctx, "DownstreamCall."+downstream)
+	defer span.End()
+
+	requestCount := m.requestCount.With("downstream", downstream, "transport", transport)
+	latencyHistogram := m.latencyHistogram.With("downstream", downstream, "transport", transport)
+
+	begin := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/", instance), nil)
+	if err != nil {
+		logger.Log("err", err, "msg", "failed to build downstream request", "downstream", downstream)
+		return
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := http.DefaultClient.Do(req)
+	requestCount.Add(1)
+	latencyHistogram.Observe(time.Since(begin).Seconds())
+	if err != nil {
+		logger.Log("err", err, "msg", "downstream call failed", "downstream", downstream)
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+
+	"github.com/MoonLightV/VECROsim/container_images/vecro-mongodb/pb"
+)
+
+// grpcServer adapts the shared baseEndpoint onto the generated gRPC
+// server interface, reusing the same go-kit encode/decode pattern as the
+// HTTP transport.
+type grpcServer struct {
+	pb.UnimplementedBaseServiceServer
+	doBase grpctransport.Handler
+}
+
+// newGRPCServer wraps endpoint with the go-kit gRPC transport so it can be
+// registered against a *grpc.Server alongside the HTTP listener.
+func newGRPCServer(ep endpoint.Endpoint) pb.BaseServiceServer {
+	return &grpcServer{
+		doBase: grpctransport.NewServer(
+			ep,
+			decodeGRPCBaseRequest,
+			encodeGRPCBaseResponse,
+		),
+	}
+}
+
+func (g *grpcServer) DoBase(ctx context.Context, req *pb.BaseRequest) (*pb.BaseResponse, error) {
+	_, resp, err := g.doBase.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp.(*pb.BaseResponse), nil
+}
+
+func decodeGRPCBaseRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return baseRequest{}, nil
+}
+
+func encodeGRPCBaseResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(baseResponse)
+	return &pb.BaseResponse{Err: resp.Err}, nil
+}
+
+// newGRPCServerTransport builds the *grpc.Server with the OTel interceptors
+// installed so traces started by an HTTP caller continue across a
+// downstream gRPC hop.
+func newGRPCServerTransport(svc pb.BaseServiceServer) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor()),
+	)
+	pb.RegisterBaseServiceServer(srv, svc)
+	return srv
+}
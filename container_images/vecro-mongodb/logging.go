@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	kitlog "github.com/go-kit/kit/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newSlogLogger builds the process-wide structured logger, emitting JSON
+// when format is "json" and logfmt-style text otherwise.
+func newSlogLogger(format string, dedupWindow time.Duration, subsystem, name string) *slog.Logger {
+	var base slog.Handler
+	if format == "json" {
+		base = slog.NewJSONHandler(os.Stderr, nil)
+	} else {
+		base = slog.NewTextHandler(os.Stderr, nil)
+	}
+
+	logsSuppressed := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "vecro_base",
+		Subsystem: subsystem,
+		Name:      "logs_suppressed_total",
+		Help:      "Number of log records suppressed as duplicates.",
+		ConstLabels: map[string]string{
+			"vecrosim_service_name": name,
+		},
+	}, []string{"reason"})
+
+	return slog.New(newDedupHandler(base, dedupWindow, logsSuppressed))
+}
+
+// dedupState is the suppression state shared by a dedupHandler and every
+// child handler derived from it via WithAttrs/WithGroup, so a caller
+// using the common logger.With(...) idiom per log call doesn't reset
+// suppression on every call.
+type dedupState struct {
+	mu       sync.Mutex
+	lastKey  string
+	lastSeen time.Time
+}
+
+// dedupHandler wraps another slog.Handler and suppresses duplicate
+// consecutive records (same level + message + attribute hash) seen
+// again within window, so a noisy caller can't flood stderr.
+type dedupHandler struct {
+	next   slog.Handler
+	window time.Duration
+	state  *dedupState
+
+	suppressed *kitprometheus.Counter
+}
+
+func newDedupHandler(next slog.Handler, window time.Duration, suppressed *kitprometheus.Counter) *dedupHandler {
+	if window <= 0 {
+		window = time.Second
+	}
+	return &dedupHandler{next: next, window: window, state: &dedupState{}, suppressed: suppressed}
+}
+
+func (h *dedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *dedupHandler) Handle(ctx context.Context, record slog.Record) error {
+	key := dedupKey(record)
+
+	h.state.mu.Lock()
+	now := record.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	duplicate := key == h.state.lastKey && now.Sub(h.state.lastSeen) < h.window
+	h.state.lastKey = key
+	h.state.lastSeen = now
+	h.state.mu.Unlock()
+
+	if duplicate {
+		h.suppressed.With("reason", "dedup").Add(1)
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dedupHandler{next: h.next.WithAttrs(attrs), window: h.window, state: h.state, suppressed: h.suppressed}
+}
+
+func (h *dedupHandler) WithGroup(name string) slog.Handler {
+	return &dedupHandler{next: h.next.WithGroup(name), window: h.window, state: h.state, suppressed: h.suppressed}
+}
+
+func dedupKey(record slog.Record) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s", record.Level, record.Message)
+	record.Attrs(func(attr slog.Attr) bool {
+		fmt.Fprintf(hash, "|%s=%v", attr.Key, attr.Value)
+		return true
+	})
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// traceContextAttrs pulls trace_id/span_id off the OTel span carried in
+// ctx so log lines can be correlated with Jaeger traces.
+func traceContextAttrs(ctx context.Context) []any {
+	span := trace.SpanContextFromContext(ctx)
+	if !span.IsValid() {
+		return nil
+	}
+	return []any{"trace_id", span.TraceID().String(), "span_id", span.SpanID().String()}
+}
+
+// slogAsKitLogger adapts a *slog.Logger onto go-kit's log.Logger
+// interface so existing middleware call sites (logger.Log("k", v)) keep
+// compiling unchanged.
+type slogAsKitLogger struct {
+	logger *slog.Logger
+}
+
+func slogAsKitLoggerWith(logger *slog.Logger) kitlog.Logger {
+	return slogAsKitLogger{logger: logger}
+}
+
+// Log adapts a go-kit keyval sequence onto slog, pulling "level" and
+// "msg" out of keyvals instead of flattening every record to Info — a
+// caller passing "level", "warn" (as slow_ops.go does) now actually
+// produces an slog.LevelWarn record rather than an Info record carrying
+// a redundant level attribute.
+func (l slogAsKitLogger) Log(keyvals ...interface{}) error {
+	level := slog.LevelInfo
+	msg := ""
+	attrs := make([]interface{}, 0, len(keyvals))
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			attrs = append(attrs, keyvals[i], keyvals[i+1])
+			continue
+		}
+		switch key {
+		case "level":
+			level = kitLevelToSlog(keyvals[i+1])
+		case "msg":
+			if s, ok := keyvals[i+1].(string); ok {
+				msg = s
+			} else {
+				attrs = append(attrs, key, keyvals[i+1])
+			}
+		default:
+			attrs = append(attrs, key, keyvals[i+1])
+		}
+	}
+	if len(keyvals)%2 == 1 {
+		attrs = append(attrs, keyvals[len(keyvals)-1])
+	}
+	l.logger.Log(context.Background(), level, msg, attrs...)
+	return nil
+}
+
+// kitLevelToSlog maps the string go-kit callers pass as the "level"
+// keyval onto the matching slog.Level, defaulting to Info for anything
+// unrecognized (including callers that don't pass one at all).
+func kitLevelToSlog(v interface{}) slog.Level {
+	switch fmt.Sprint(v) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error", "err":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -2,11 +2,17 @@ package main
 
 import (
 	"context"
+	"log/slog"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
-	slog "log"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/go-kit/kit/sd/consul"
 
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -41,9 +47,9 @@ func initTracer() func(context.Context) error {
 		jaeger.WithEndpoint("http://jaeger-collector:14268/api/traces"),
 	))
 	if err != nil {
-		slog.Printf("failed to create Jaeger exporter: %v", err)
+		slog.Error("failed to create jaeger exporter", "err", err)
 	} else {
-		slog.Println("success to build jaeger")
+		slog.Info("success to build jaeger")
 	}
 
 	tp := sdktrace.NewTracerProvider(
@@ -78,20 +84,29 @@ func tracingMiddleware(tracerName, spanName string) endpoint.Middleware {
 }
 
 func main() {
-	shutdown := initTracer()
-	defer shutdown(context.Background())
 	// -------------------
 	// Declare constants
 	// -------------------
 	const (
-		nameEnvKey          = "VECRO_NAME"
-		subsystemEnvKey     = "VECRO_SUBSYSTEM"
-		listenAddressEnvKey = "VECRO_LISTEN_ADDRESS"
-		dbReadOpsEnvKey     = "VECRO_DB_READ_OPS"
-		dbWriteOpsEnvKey    = "VECRO_DB_WRITE_OPS"
-		dbUserEnvKey        = "VECRO_DB_USER"
-		dbPasswordEnvKey    = "VECRO_DB_PASSWORD"
-		dbCollectionEnvKey  = "VECRO_DB_COLLECTION"
+		nameEnvKey           = "VECRO_NAME"
+		subsystemEnvKey      = "VECRO_SUBSYSTEM"
+		listenAddressEnvKey  = "VECRO_LISTEN_ADDRESS"
+		grpcListenAddressKey = "VECRO_GRPC_LISTEN_ADDRESS"
+		dbReadOpsEnvKey      = "VECRO_DB_READ_OPS"
+		dbWriteOpsEnvKey     = "VECRO_DB_WRITE_OPS"
+		dbUserEnvKey         = "VECRO_DB_USER"
+		dbPasswordEnvKey     = "VECRO_DB_PASSWORD"
+		dbCollectionEnvKey   = "VECRO_DB_COLLECTION"
+		cacheAddrEnvKey      = "VECRO_CACHE_ADDR"
+		cacheHitRatioEnvKey  = "VECRO_CACHE_HIT_RATIO"
+		cacheTTLEnvKey       = "VECRO_CACHE_TTL"
+		dbBatchSizeEnvKey    = "VECRO_DB_BATCH_SIZE"
+		dbBatchIntervalKey   = "VECRO_DB_BATCH_INTERVAL"
+		consulAddrEnvKey     = "VECRO_CONSUL_ADDR"
+		downstreamsEnvKey    = "VECRO_DOWNSTREAM_SERVICES"
+		logFormatEnvKey      = "VECRO_LOG_FORMAT"
+		logDedupWindowKey    = "VECRO_LOG_DEDUP_WINDOW"
+		slowOpThresholdKey   = "VECRO_SLOW_OP_THRESHOLD"
 	)
 
 	const databaseName = "data"
@@ -100,10 +115,24 @@ func main() {
 	// -------------------
 	// Init logging
 	// -------------------
+	logFormat, _ := getEnvString(logFormatEnvKey, "logfmt")
+	subsystem, _ := getEnvString(subsystemEnvKey, "subsystem")
+	name, _ := getEnvString(nameEnvKey, "name")
+
+	logDedupWindow, err := time.ParseDuration(os.Getenv(logDedupWindowKey))
+	if err != nil || logDedupWindow <= 0 {
+		logDedupWindow = time.Second
+	}
+	slogLogger := newSlogLogger(logFormat, logDedupWindow, subsystem, name)
+	slog.SetDefault(slogLogger)
+
 	var logger log.Logger
-	logger = log.NewLogfmtLogger(os.Stderr)
+	logger = slogAsKitLoggerWith(slogLogger)
 	logger = log.With(logger, "caller", log.DefaultCaller)
 
+	shutdown := initTracer()
+	defer shutdown(context.Background())
+
 	// -------------------
 	// Parse Environment variables
 	// -------------------
@@ -120,18 +149,11 @@ func main() {
 	dbPassword, _ = getEnvString(dbPasswordEnvKey, "")
 	dbCollection, _ = getEnvString(dbCollectionEnvKey, "")
 
-	slog.Println("Info db read ops:", dbReadOps)
-	slog.Println("Info db write ops:", dbWriteOps)
-	slog.Println("Info db user:", dbUser)
-	slog.Println("Info db password:", dbPassword)
-	slog.Println("Info db collection:", dbCollection)
+	slog.Info("db config", "read_ops", dbReadOps, "write_ops", dbWriteOps, "user", dbUser, "collection", dbCollection)
 
 	listenAddress, _ := getEnvString(listenAddressEnvKey, ":8080")
-	slog.Println("Info listen_address:", listenAddress)
-
-	subsystem, _ := getEnvString(subsystemEnvKey, "subsystem")
-	name, _ := getEnvString(nameEnvKey, "name")
-	slog.Println("Info name:", name, "subsystem:", subsystem)
+	slog.Info("listen_address", "addr", listenAddress)
+	slog.Info("service identity", "name", name, "subsystem", subsystem)
 
 	// -------------------
 	// Init Prometheus counter & histogram
@@ -144,7 +166,7 @@ func main() {
 		ConstLabels: map[string]string{
 			"vecrosim_service_name": name,
 		},
-	}, nil)
+	}, []string{"transport"})
 	latencyCounter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
 		Namespace: "vecro_base",
 		Subsystem: subsystem,
@@ -153,7 +175,7 @@ func main() {
 		ConstLabels: map[string]string{
 			"vecrosim_service_name": name,
 		},
-	}, nil)
+	}, []string{"transport"})
 	latencyHistogram := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 		Namespace: "vecro_base",
 		Subsystem: subsystem,
@@ -164,7 +186,7 @@ func main() {
 		ConstLabels: map[string]string{
 			"vecrosim_service_name": name,
 		},
-	}, nil)
+	}, []string{"transport"})
 	throughput := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
 		Namespace: "vecro_base",
 		Subsystem: subsystem,
@@ -173,7 +195,7 @@ func main() {
 		ConstLabels: map[string]string{
 			"vecrosim_service_name": name,
 		},
-	}, nil)
+	}, []string{"transport"})
 
 	// -------------------
 	// Init database connection
@@ -200,6 +222,71 @@ func main() {
 
 	collection := client.Database(databaseName).Collection(collectionName)
 
+	// -------------------
+	// Init optional Redis cache tier
+	// -------------------
+	var cacheClient *redis.Client
+	cacheAddr, _ := getEnvString(cacheAddrEnvKey, "")
+	if cacheAddr != "" {
+		cacheClient = redis.NewClient(&redis.Options{Addr: cacheAddr})
+		slog.Info("cache enabled", "addr", cacheAddr)
+	}
+	cacheHitRatio, err := strconv.ParseFloat(os.Getenv(cacheHitRatioEnvKey), 64)
+	if err != nil {
+		cacheHitRatio = 0
+	}
+	cacheTTL, err := time.ParseDuration(os.Getenv(cacheTTLEnvKey))
+	if err != nil {
+		cacheTTL = 30 * time.Second
+	}
+	// Constructed once regardless of transport count: go-kit's
+	// NewCounterFrom/NewHistogramFrom register with the default
+	// Prometheus registry, so building these per-transport would panic
+	// on the second (duplicate) registration.
+	cacheMetricsCollectors := newCacheMetrics(subsystem, name)
+
+	// -------------------
+	// Init batched Mongo writer
+	// -------------------
+	dbBatchSize, _ := getEnvInt(dbBatchSizeEnvKey, 1)
+	dbBatchInterval, err := time.ParseDuration(os.Getenv(dbBatchIntervalKey))
+	if err != nil || dbBatchInterval <= 0 {
+		dbBatchInterval = time.Second
+	}
+	writeBatcher := newBatchWriter(collection, dbBatchSize, dbBatchInterval, subsystem, name, logger)
+	defer writeBatcher.Shutdown()
+
+	// -------------------
+	// Init slow-operation timer for Mongo reads/writes
+	// -------------------
+	slowOpThreshold, _ := time.ParseDuration(os.Getenv(slowOpThresholdKey))
+	dbOpTimer := newOpTimer(slowOpThreshold, subsystem, name, logger)
+
+	// -------------------
+	// Optional Consul registration and downstream call mode
+	// -------------------
+	var downstreamServices []string
+	if downstreams, _ := getEnvString(downstreamsEnvKey, ""); downstreams != "" {
+		downstreamServices = strings.Split(downstreams, ",")
+	}
+
+	// Constructed once regardless of transport count, for the same reason
+	// as cacheMetricsCollectors above: a second registration of the same
+	// collector panics.
+	downstreamMetricsCollectors := newDownstreamMetrics(subsystem, name)
+
+	var consulClient *consul.Client
+	if consulAddr, _ := getEnvString(consulAddrEnvKey, ""); consulAddr != "" {
+		var deregister func()
+		var cErr error
+		consulClient, _, deregister, cErr = registerConsulService(consulAddr, name, listenAddress, logger)
+		if cErr != nil {
+			slog.Error("failed to register with consul", "err", cErr)
+		} else {
+			defer deregister()
+		}
+	}
+
 	// Seed random number generator
 	rand.Seed(time.Now().UnixNano())
 
@@ -211,12 +298,25 @@ func main() {
 		dbCollection: collection,
 		dbReadOps:    dbReadOps,
 		dbWriteOps:   dbWriteOps,
+		writeBatcher: writeBatcher,
+		opTimer:      dbOpTimer,
+	}
+	if cacheClient != nil {
+		svc = cachingMiddleware(cacheClient, cacheHitRatio, cacheTTL, cacheMetricsCollectors, "http", logger)(svc)
 	}
 	svc = loggingMiddleware(logger)(svc)
-	svc = instrumentingMiddleware(requestCount, latencyCounter, latencyHistogram, logger)(svc)
+	svc = instrumentingMiddleware(
+		requestCount.With("transport", "http"),
+		latencyCounter.With("transport", "http"),
+		latencyHistogram.With("transport", "http"),
+		logger,
+	)(svc)
 
 	baseEndpoint := makeBaseEndPoint(svc)
 	baseEndpoint = tracingMiddleware("vecro-service", "BaseRequest")(baseEndpoint)
+	if consulClient != nil && len(downstreamServices) > 0 {
+		baseEndpoint = downstreamMiddleware(consulClient, downstreamServices, downstreamMetricsCollectors, "http", logger)(baseEndpoint)
+	}
 
 	baseHandler := httptransport.NewServer(
 		baseEndpoint,
@@ -228,13 +328,58 @@ func main() {
 		// Request throughput instrumentation
 		httptransport.ServerFinalizer(func(ctx context.Context, code int, r *http.Request) {
 			responseSize := ctx.Value(httptransport.ContextKeyResponseSize).(int64)
-			slog.Println("Info reponse_size:", responseSize)
-			throughput.Add(float64(responseSize))
+			slog.Info("response_size", "bytes", responseSize)
+			throughput.With("transport", "http").Add(float64(responseSize))
 		}),
 	)
 
+	// -------------------
+	// Optionally start a parallel gRPC server over the same service, so
+	// simulated services can be chained over gRPC as well as HTTP.
+	// -------------------
+	if grpcListenAddress, _ := getEnvString(grpcListenAddressKey, ""); grpcListenAddress != "" {
+		var grpcSvc BaseService
+		grpcSvc = baseService{
+			dbCollection: collection,
+			dbReadOps:    dbReadOps,
+			dbWriteOps:   dbWriteOps,
+			writeBatcher: writeBatcher,
+			opTimer:      dbOpTimer,
+		}
+		if cacheClient != nil {
+			grpcSvc = cachingMiddleware(cacheClient, cacheHitRatio, cacheTTL, cacheMetricsCollectors, "grpc", logger)(grpcSvc)
+		}
+		grpcSvc = loggingMiddleware(logger)(grpcSvc)
+		grpcSvc = instrumentingMiddleware(
+			requestCount.With("transport", "grpc"),
+			latencyCounter.With("transport", "grpc"),
+			latencyHistogram.With("transport", "grpc"),
+			logger,
+		)(grpcSvc)
+
+		grpcEndpoint := makeBaseEndPoint(grpcSvc)
+		grpcEndpoint = tracingMiddleware("vecro-service", "BaseRequest")(grpcEndpoint)
+		if consulClient != nil && len(downstreamServices) > 0 {
+			grpcEndpoint = downstreamMiddleware(consulClient, downstreamServices, downstreamMetricsCollectors, "grpc", logger)(grpcEndpoint)
+		}
+
+		grpcListener, err := net.Listen("tcp", grpcListenAddress)
+		if err != nil {
+			slog.Error("failed to bind grpc listener", "err", err)
+		} else {
+			grpcServerTransport := newGRPCServerTransport(newGRPCServer(grpcEndpoint))
+			go func() {
+				slog.Info("listening", "transport", "grpc", "addr", grpcListenAddress)
+				logger.Log("err", grpcServerTransport.Serve(grpcListener))
+			}()
+		}
+	}
+
 	http.Handle("/", baseHandler)
 	http.Handle("/metrics", promhttp.Handler())
-	slog.Println("Info msg:", "HTTP", "addr:", listenAddress)
+	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	slog.Info("listening", "transport", "http", "addr", listenAddress)
 	logger.Log("err", http.ListenAndServe(listenAddress, nil))
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BaseService is the innermost unit of work every VECROsim container
+// simulates: dbReadOps Mongo reads and dbWriteOps Mongo writes per call,
+// the same knobs operators use to shape synthetic load without
+// redeploying code.
+type BaseService interface {
+	DoBase(ctx context.Context) error
+}
+
+// ServiceMiddleware wraps a BaseService with cross-cutting behavior
+// (caching, logging, instrumentation), mirroring go-kit's
+// endpoint.Middleware shape one layer down the stack.
+type ServiceMiddleware func(BaseService) BaseService
+
+// baseService performs the configured number of Mongo reads and writes
+// per call. Reads run directly against dbCollection; writes are handed
+// to writeBatcher so they're committed via BulkWrite instead of one at a
+// time. Every individual op is timed through opTimer and shows up in
+// db_op_latency_seconds/db_slow_ops_total; for writes the timed op is the
+// handoff to writeBatcher (including any wait for buffer space), not the
+// BulkWrite itself.
+type baseService struct {
+	dbCollection *mongo.Collection
+	dbReadOps    int
+	dbWriteOps   int
+	writeBatcher *batchWriter
+	opTimer      *opTimer
+}
+
+func (s baseService) DoBase(ctx context.Context) error {
+	for i := 0; i < s.dbReadOps; i++ {
+		err := s.opTimer.Time(ctx, "read", s.dbCollection.Name(), func() error {
+			err := s.dbCollection.FindOne(ctx, bson.M{}).Err()
+			if err == mongo.ErrNoDocuments {
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < s.dbWriteOps; i++ {
+		err := s.opTimer.Time(ctx, "write", s.dbCollection.Name(), func() error {
+			s.writeBatcher.Enqueue(bson.M{})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
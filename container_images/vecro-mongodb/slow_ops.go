@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// opTimer wraps individual Mongo read/write calls, recording a per-op
+// latency histogram and, when threshold is positive, warning on any op
+// that runs longer than it — mirroring the slow-SQL-threshold pattern
+// used to diagnose which simulated dependency is the bottleneck.
+type opTimer struct {
+	threshold time.Duration
+	logger    log.Logger
+
+	latency *kitprometheus.Histogram
+	slowOps *kitprometheus.Counter
+}
+
+func newOpTimer(threshold time.Duration, subsystem, name string, logger log.Logger) *opTimer {
+	return &opTimer{
+		threshold: threshold,
+		logger:    logger,
+		latency: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "db_op_latency_seconds",
+			Help:      "Latency of individual Mongo read/write operations in seconds.",
+			Buckets:   []float64{.0002, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 15, 25},
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"op"}),
+		slowOps: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "vecro_base",
+			Subsystem: subsystem,
+			Name:      "db_slow_ops_total",
+			Help:      "Number of Mongo operations that exceeded VECRO_SLOW_OP_THRESHOLD.",
+			ConstLabels: map[string]string{
+				"vecrosim_service_name": name,
+			},
+		}, []string{"op"}),
+	}
+}
+
+// Time runs fn, recording its latency under op ("read" or "write") and,
+// if it exceeds the configured threshold, logging a warning with the
+// current trace/span IDs so operators can correlate it with a trace.
+// Called from baseService wrapping each Mongo FindOne call (op "read")
+// and each writeBatcher.Enqueue handoff (op "write"); the BulkWrite that
+// later commits the batch runs in batchWriter.flush and is not timed here.
+func (t *opTimer) Time(ctx context.Context, op, collection string, fn func() error) error {
+	begin := time.Now()
+	err := fn()
+	elapsed := time.Since(begin)
+
+	t.latency.With("op", op).Observe(elapsed.Seconds())
+
+	if t.threshold > 0 && elapsed > t.threshold {
+		t.slowOps.With("op", op).Add(1)
+		keyvals := []interface{}{
+			"level", "warn",
+			"msg", "slow mongo operation",
+			"op", op,
+			"collection", collection,
+			"elapsed_ms", elapsed.Milliseconds(),
+		}
+		keyvals = append(keyvals, traceContextAttrs(ctx)...)
+		t.logger.Log(keyvals...)
+	}
+
+	return err
+}